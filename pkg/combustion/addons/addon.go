@@ -0,0 +1,58 @@
+// Package addons implements the curated catalog of optional Kubernetes add-ons that can be
+// enabled through image.Kubernetes.Addons. Each add-on is a self-contained, versioned bundle
+// of manifests/HelmCharts plus the container images it needs, so that selecting it is enough
+// to make it available air-gapped.
+package addons
+
+// Manifest is a single rendered manifest file destined for the cluster's manifests directory.
+type Manifest struct {
+	Name string
+	Data []byte
+}
+
+// Addon is a named, versioned bundle of Kubernetes manifests. Implementations register
+// themselves with Register from an init function so that third-party add-ons can be added to
+// the catalog without modifying the combustion package.
+type Addon interface {
+	// Name returns the add-on's catalog identifier, e.g. "metallb".
+	Name() string
+
+	// Render expands the add-on's embedded templates using the supplied per-addon value
+	// overrides and returns the rendered manifests together with the list of container images
+	// it references, so the caller can queue them for air-gapped pre-pull.
+	Render(values map[string]any) ([]Manifest, []string, error)
+}
+
+// helmRepoMirrorKey is the values key the caller sets, when the build has an embedded Helm
+// chart mirror configured, to redirect a HelmChart CR's repo away from the live upstream.
+const helmRepoMirrorKey = "helmRepoMirror"
+
+// chartRepo describes a single add-on's upstream Helm chart: where it normally comes from,
+// its chart name within that repo, and the exact version pinned for this catalog entry.
+type chartRepo struct {
+	Repo    string
+	Chart   string
+	Version string
+}
+
+// helmChartValues returns a copy of values augmented with chartName/chartVersion/chartRepo,
+// for templates to render into a HelmChart CR's spec. chartRepo.Repo is used unless the
+// caller supplied an embedded mirror via helmRepoMirrorKey, in which case the chart is
+// pulled from the mirror instead of live upstream.
+func helmChartValues(values map[string]any, repo chartRepo) map[string]any {
+	merged := make(map[string]any, len(values)+3)
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	merged["chartName"] = repo.Chart
+	merged["chartVersion"] = repo.Version
+
+	if mirror, ok := values[helmRepoMirrorKey].(string); ok && mirror != "" {
+		merged["chartRepo"] = mirror
+	} else {
+		merged["chartRepo"] = repo.Repo
+	}
+
+	return merged
+}
@@ -0,0 +1,44 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	certManagerVersion = "v1.15.3"
+	certManagerChart   = "cert-manager"
+	certManagerRepo    = "https://charts.jetstack.io"
+)
+
+//go:embed templates/cert-manager.yaml.tpl
+var certManagerManifest string
+
+type certManagerAddon struct{}
+
+func init() {
+	Register(&certManagerAddon{})
+}
+
+func (*certManagerAddon) Name() string {
+	return "cert-manager"
+}
+
+func (*certManagerAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: certManagerRepo, Chart: certManagerChart, Version: certManagerVersion}
+	data, err := template.Parse("cert-manager", certManagerManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing cert-manager manifest: %w", err)
+	}
+
+	images := []string{
+		"quay.io/jetstack/cert-manager-controller:" + certManagerVersion,
+		"quay.io/jetstack/cert-manager-webhook:" + certManagerVersion,
+		"quay.io/jetstack/cert-manager-cainjector:" + certManagerVersion,
+		"quay.io/jetstack/cert-manager-startupapicheck:" + certManagerVersion,
+	}
+
+	return []Manifest{{Name: "cert-manager.yaml", Data: []byte(data)}}, images, nil
+}
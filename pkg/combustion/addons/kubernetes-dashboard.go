@@ -0,0 +1,43 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	kubernetesDashboardVersion      = "v2.7.0"
+	kubernetesDashboardChartVersion = "7.5.0"
+	kubernetesDashboardChart        = "kubernetes-dashboard"
+	kubernetesDashboardRepo         = "https://kubernetes.github.io/dashboard"
+)
+
+//go:embed templates/kubernetes-dashboard.yaml.tpl
+var kubernetesDashboardManifest string
+
+type kubernetesDashboardAddon struct{}
+
+func init() {
+	Register(&kubernetesDashboardAddon{})
+}
+
+func (*kubernetesDashboardAddon) Name() string {
+	return "kubernetes-dashboard"
+}
+
+func (*kubernetesDashboardAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: kubernetesDashboardRepo, Chart: kubernetesDashboardChart, Version: kubernetesDashboardChartVersion}
+	data, err := template.Parse("kubernetes-dashboard", kubernetesDashboardManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing kubernetes-dashboard manifest: %w", err)
+	}
+
+	images := []string{
+		"kubernetesui/dashboard:" + kubernetesDashboardVersion,
+		"kubernetesui/metrics-scraper:v1.0.8",
+	}
+
+	return []Manifest{{Name: "kubernetes-dashboard.yaml", Data: []byte(data)}}, images, nil
+}
@@ -0,0 +1,41 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	localPathProvisionerVersion = "v0.0.30"
+	localPathProvisionerChart   = "local-path-provisioner"
+	localPathProvisionerRepo    = "https://charts.containeroo.ch"
+)
+
+//go:embed templates/local-path-provisioner.yaml.tpl
+var localPathProvisionerManifest string
+
+type localPathProvisionerAddon struct{}
+
+func init() {
+	Register(&localPathProvisionerAddon{})
+}
+
+func (*localPathProvisionerAddon) Name() string {
+	return "local-path-provisioner"
+}
+
+func (*localPathProvisionerAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: localPathProvisionerRepo, Chart: localPathProvisionerChart, Version: localPathProvisionerVersion}
+	data, err := template.Parse("local-path-provisioner", localPathProvisionerManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing local-path-provisioner manifest: %w", err)
+	}
+
+	images := []string{
+		"rancher/local-path-provisioner:" + localPathProvisionerVersion,
+	}
+
+	return []Manifest{{Name: "local-path-provisioner.yaml", Data: []byte(data)}}, images, nil
+}
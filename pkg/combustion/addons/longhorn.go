@@ -0,0 +1,49 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	longhornVersion = "v1.7.1"
+	longhornChart   = "longhorn"
+	longhornRepo    = "https://charts.longhorn.io"
+)
+
+//go:embed templates/longhorn.yaml.tpl
+var longhornManifest string
+
+type longhornAddon struct{}
+
+func init() {
+	Register(&longhornAddon{})
+}
+
+func (*longhornAddon) Name() string {
+	return "longhorn"
+}
+
+func (*longhornAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: longhornRepo, Chart: longhornChart, Version: longhornVersion}
+	data, err := template.Parse("longhorn", longhornManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing longhorn manifest: %w", err)
+	}
+
+	images := []string{
+		"longhornio/longhorn-manager:" + longhornVersion,
+		"longhornio/longhorn-engine:" + longhornVersion,
+		"longhornio/longhorn-ui:" + longhornVersion,
+		"longhornio/longhorn-instance-manager:" + longhornVersion,
+		"longhornio/csi-provisioner:v5.0.1",
+		"longhornio/csi-attacher:v4.6.1",
+		"longhornio/csi-resizer:v1.11.1",
+		"longhornio/csi-snapshotter:v7.0.2",
+		"longhornio/csi-node-driver-registrar:v2.10.1",
+	}
+
+	return []Manifest{{Name: "longhorn.yaml", Data: []byte(data)}}, images, nil
+}
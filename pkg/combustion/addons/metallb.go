@@ -0,0 +1,42 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	metallbVersion = "v0.14.8"
+	metallbChart   = "metallb"
+	metallbRepo    = "https://metallb.github.io/metallb"
+)
+
+//go:embed templates/metallb.yaml.tpl
+var metallbManifest string
+
+type metallbAddon struct{}
+
+func init() {
+	Register(&metallbAddon{})
+}
+
+func (*metallbAddon) Name() string {
+	return "metallb"
+}
+
+func (*metallbAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: metallbRepo, Chart: metallbChart, Version: metallbVersion}
+	data, err := template.Parse("metallb", metallbManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing metallb manifest: %w", err)
+	}
+
+	images := []string{
+		"quay.io/metallb/controller:" + metallbVersion,
+		"quay.io/metallb/speaker:" + metallbVersion,
+	}
+
+	return []Manifest{{Name: "metallb.yaml", Data: []byte(data)}}, images, nil
+}
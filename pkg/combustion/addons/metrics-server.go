@@ -0,0 +1,42 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	metricsServerVersion      = "v0.7.2"
+	metricsServerChartVersion = "3.12.1"
+	metricsServerChart        = "metrics-server"
+	metricsServerRepo         = "https://kubernetes-sigs.github.io/metrics-server"
+)
+
+//go:embed templates/metrics-server.yaml.tpl
+var metricsServerManifest string
+
+type metricsServerAddon struct{}
+
+func init() {
+	Register(&metricsServerAddon{})
+}
+
+func (*metricsServerAddon) Name() string {
+	return "metrics-server"
+}
+
+func (*metricsServerAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: metricsServerRepo, Chart: metricsServerChart, Version: metricsServerChartVersion}
+	data, err := template.Parse("metrics-server", metricsServerManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing metrics-server manifest: %w", err)
+	}
+
+	images := []string{
+		"registry.k8s.io/metrics-server/metrics-server:" + metricsServerVersion,
+	}
+
+	return []Manifest{{Name: "metrics-server.yaml", Data: []byte(data)}}, images, nil
+}
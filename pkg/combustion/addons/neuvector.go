@@ -0,0 +1,46 @@
+package addons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/suse-edge/edge-image-builder/pkg/template"
+)
+
+const (
+	neuvectorVersion        = "5.3.3"
+	neuvectorUpdaterVersion = "1.0.0"
+	neuvectorChartVersion   = "2.7.8"
+	neuvectorChart          = "core"
+	neuvectorRepo           = "https://neuvector.github.io/neuvector-helm"
+)
+
+//go:embed templates/neuvector.yaml.tpl
+var neuvectorManifest string
+
+type neuvectorAddon struct{}
+
+func init() {
+	Register(&neuvectorAddon{})
+}
+
+func (*neuvectorAddon) Name() string {
+	return "neuvector"
+}
+
+func (*neuvectorAddon) Render(values map[string]any) ([]Manifest, []string, error) {
+	chart := chartRepo{Repo: neuvectorRepo, Chart: neuvectorChart, Version: neuvectorChartVersion}
+	data, err := template.Parse("neuvector", neuvectorManifest, helmChartValues(values, chart))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing neuvector manifest: %w", err)
+	}
+
+	images := []string{
+		"neuvector/controller:" + neuvectorVersion,
+		"neuvector/enforcer:" + neuvectorVersion,
+		"neuvector/manager:" + neuvectorVersion,
+		"neuvector/updater:" + neuvectorUpdaterVersion,
+	}
+
+	return []Manifest{{Name: "neuvector.yaml", Data: []byte(data)}}, images, nil
+}
@@ -0,0 +1,25 @@
+package addons
+
+var catalog = map[string]Addon{}
+
+// Register adds an Addon to the catalog. It is called from each built-in add-on's init
+// function; third-party add-ons can call it the same way from their own package.
+func Register(addon Addon) {
+	catalog[addon.Name()] = addon
+}
+
+// Get looks up a registered add-on by its catalog name.
+func Get(name string) (Addon, bool) {
+	addon, ok := catalog[name]
+	return addon, ok
+}
+
+// Names returns the catalog identifiers of every registered add-on.
+func Names() []string {
+	names := make([]string, 0, len(catalog))
+	for name := range catalog {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,57 @@
+package addons
+
+import "testing"
+
+type fakeAddon struct {
+	name string
+}
+
+func (a *fakeAddon) Name() string {
+	return a.name
+}
+
+func (a *fakeAddon) Render(map[string]any) ([]Manifest, []string, error) {
+	return nil, nil, nil
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	original := catalog
+	catalog = map[string]Addon{}
+	defer func() { catalog = original }()
+
+	addon := &fakeAddon{name: "test-addon"}
+	Register(addon)
+
+	got, ok := Get("test-addon")
+	if !ok {
+		t.Fatal("expected registered addon to be found")
+	}
+	if got.Name() != "test-addon" {
+		t.Fatalf("expected name %q, got %q", "test-addon", got.Name())
+	}
+}
+
+func TestGetUnknownAddon(t *testing.T) {
+	original := catalog
+	catalog = map[string]Addon{}
+	defer func() { catalog = original }()
+
+	_, ok := Get("does-not-exist")
+	if ok {
+		t.Fatal("expected unknown addon name to not be found")
+	}
+}
+
+func TestNames(t *testing.T) {
+	original := catalog
+	catalog = map[string]Addon{}
+	defer func() { catalog = original }()
+
+	Register(&fakeAddon{name: "a"})
+	Register(&fakeAddon{name: "b"})
+
+	names := Names()
+	if len(names) != 2 {
+		t.Fatalf("expected 2 registered names, got %v", names)
+	}
+}
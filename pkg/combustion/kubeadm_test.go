@@ -0,0 +1,202 @@
+package combustion
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKubeadmClusterConfiguration(t *testing.T) {
+	config := map[string]any{
+		"cluster-cidr": "10.42.0.0/16",
+		"service-cidr": "10.43.0.0/16",
+		"tls-san":      []string{"api.example.com"},
+	}
+
+	clusterConfig := kubeadmClusterConfiguration(config)
+
+	if clusterConfig["kind"] != "ClusterConfiguration" {
+		t.Fatalf("expected kind ClusterConfiguration, got %v", clusterConfig["kind"])
+	}
+
+	networking, ok := clusterConfig["networking"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected networking map, got %v", clusterConfig["networking"])
+	}
+
+	if networking["podSubnet"] != "10.42.0.0/16" || networking["serviceSubnet"] != "10.43.0.0/16" {
+		t.Fatalf("unexpected networking block: %v", networking)
+	}
+
+	apiServer, ok := clusterConfig["apiServer"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected apiServer map, got %v", clusterConfig["apiServer"])
+	}
+
+	sans, _ := apiServer["certSANs"].([]string)
+	if len(sans) != 1 || sans[0] != "api.example.com" {
+		t.Fatalf("expected certSANs [api.example.com], got %v", sans)
+	}
+}
+
+func TestKubeadmInitConfiguration(t *testing.T) {
+	config := map[string]any{
+		"token":   "abcdef.0123456789abcdef",
+		"node-ip": "192.168.1.10",
+	}
+
+	initConfig := kubeadmInitConfiguration(config)
+
+	if initConfig["kind"] != "InitConfiguration" {
+		t.Fatalf("expected kind InitConfiguration, got %v", initConfig["kind"])
+	}
+
+	tokens, ok := initConfig["bootstrapTokens"].([]map[string]any)
+	if !ok || len(tokens) != 1 || tokens[0]["token"] != "abcdef.0123456789abcdef" {
+		t.Fatalf("unexpected bootstrapTokens: %v", initConfig["bootstrapTokens"])
+	}
+
+	endpoint, ok := initConfig["localAPIEndpoint"].(map[string]any)
+	if !ok || endpoint["advertiseAddress"] != "192.168.1.10" {
+		t.Fatalf("unexpected localAPIEndpoint: %v", initConfig["localAPIEndpoint"])
+	}
+}
+
+func TestKubeadmJoinConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       map[string]any
+		controlPlane bool
+		expectCP     bool
+	}{
+		{
+			name:         "worker join has no controlPlane stanza",
+			config:       map[string]any{"token": "tok", "server": "https://api:6443"},
+			controlPlane: false,
+			expectCP:     false,
+		},
+		{
+			name:         "server join sets controlPlane with the local API endpoint",
+			config:       map[string]any{"token": "tok", "server": "https://api:6443", "node-ip": "192.168.1.11"},
+			controlPlane: true,
+			expectCP:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			joinConfig := kubeadmJoinConfiguration(test.config, test.controlPlane)
+
+			if joinConfig["kind"] != "JoinConfiguration" {
+				t.Fatalf("expected kind JoinConfiguration, got %v", joinConfig["kind"])
+			}
+
+			_, hasCP := joinConfig["controlPlane"]
+			if hasCP != test.expectCP {
+				t.Fatalf("expected controlPlane presence %v, got %v", test.expectCP, hasCP)
+			}
+
+			if test.expectCP {
+				controlPlane, _ := joinConfig["controlPlane"].(map[string]any)
+				endpoint, _ := controlPlane["localAPIEndpoint"].(map[string]any)
+				if endpoint["advertiseAddress"] != test.config["node-ip"] {
+					t.Fatalf("expected advertiseAddress %v, got %v", test.config["node-ip"], controlPlane)
+				}
+			}
+
+			discovery, ok := joinConfig["discovery"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected discovery map, got %v", joinConfig["discovery"])
+			}
+
+			bootstrapToken, ok := discovery["bootstrapToken"].(map[string]any)
+			if !ok || bootstrapToken["token"] != "tok" {
+				t.Fatalf("unexpected bootstrapToken: %v", discovery["bootstrapToken"])
+			}
+
+			if _, ok := bootstrapToken["unsafeSkipCAVerification"]; !ok {
+				t.Fatalf("expected unsafeSkipCAVerification fallback when no CA cert is configured, got %v", bootstrapToken)
+			}
+		})
+	}
+}
+
+func TestKubeadmJoinConfigurationUsesCACertHashWhenAvailable(t *testing.T) {
+	pemCert := generateTestCACertPEM(t)
+
+	config := map[string]any{
+		"token":   "tok",
+		"server":  "https://api:6443",
+		"ca-cert": pemCert,
+	}
+
+	joinConfig := kubeadmJoinConfiguration(config, false)
+
+	discovery := joinConfig["discovery"].(map[string]any)
+	bootstrapToken := discovery["bootstrapToken"].(map[string]any)
+
+	if _, ok := bootstrapToken["unsafeSkipCAVerification"]; ok {
+		t.Fatalf("expected unsafeSkipCAVerification to be absent when a CA cert is available, got %v", bootstrapToken)
+	}
+
+	hashes, ok := bootstrapToken["caCertHashes"].([]string)
+	if !ok || len(hashes) != 1 || !strings.HasPrefix(hashes[0], "sha256:") {
+		t.Fatalf("expected a single sha256: caCertHashes entry, got %v", bootstrapToken["caCertHashes"])
+	}
+}
+
+func TestMarshalKubeadmDocuments(t *testing.T) {
+	doc1 := map[string]any{"kind": "ClusterConfiguration"}
+	doc2 := map[string]any{"kind": "InitConfiguration"}
+
+	data, err := marshalKubeadmDocuments(doc1, doc2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rendered := string(data)
+	if !strings.Contains(rendered, "kind: ClusterConfiguration") || !strings.Contains(rendered, "kind: InitConfiguration") {
+		t.Fatalf("expected both documents to be rendered, got:\n%s", rendered)
+	}
+
+	if strings.Index(rendered, "kind: ClusterConfiguration") > strings.Index(rendered, "kind: InitConfiguration") {
+		t.Fatalf("expected documents in call order, got:\n%s", rendered)
+	}
+
+	if !strings.Contains(rendered, "---\n") {
+		t.Fatalf("expected a '---' document separator, got:\n%s", rendered)
+	}
+}
+
+func generateTestCACertPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating test certificate: %v", err)
+	}
+
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: der}
+	return string(pem.EncodeToMemory(block))
+}
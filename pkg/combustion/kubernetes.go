@@ -1,12 +1,18 @@
 package combustion
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
 	_ "embed"
+	"encoding/pem"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/suse-edge/edge-image-builder/pkg/combustion/addons"
 	"github.com/suse-edge/edge-image-builder/pkg/fileio"
 	"github.com/suse-edge/edge-image-builder/pkg/image"
 	"github.com/suse-edge/edge-image-builder/pkg/kubernetes"
@@ -34,6 +40,15 @@ const (
 	k8sAgentConfigFile      = "agent.yaml"
 
 	k8sInstallScript = "20-k8s-install.sh"
+
+	rke2ProxyDropInFile = "http-proxy.conf"
+
+	k8sTrustedCADir = "trusted-cas"
+
+	kubeVIPVersion              = "v0.7.2"
+	kubeVIPCloudProviderVersion = "v0.0.7"
+
+	kubeVIPModeBGP = "bgp"
 )
 
 var (
@@ -49,8 +64,20 @@ var (
 	//go:embed templates/k3s-multi-node-installer.sh.tpl
 	k3sMultiNodeInstaller string
 
-	//go:embed templates/k8s-vip.yaml.tpl
-	k8sVIPManifest string
+	//go:embed templates/kubeadm-single-node-installer.sh.tpl
+	kubeadmSingleNodeInstaller string
+
+	//go:embed templates/kubeadm-multi-node-installer.sh.tpl
+	kubeadmMultiNodeInstaller string
+
+	//go:embed templates/k8s-vip-arp.yaml.tpl
+	k8sVIPARPManifest string
+
+	//go:embed templates/k8s-vip-bgp.yaml.tpl
+	k8sVIPBGPManifest string
+
+	//go:embed templates/kube-vip-cloud-provider.yaml.tpl
+	kubeVIPCloudProviderManifest string
 )
 
 func (c *Combustion) configureKubernetes(ctx *image.Context) ([]string, error) {
@@ -67,6 +94,8 @@ func (c *Combustion) configureKubernetes(ctx *image.Context) ([]string, error) {
 		return nil, fmt.Errorf("cannot configure kubernetes version: %s", version)
 	}
 
+	c.applyKubernetesProxyConfig(ctx)
+
 	// Show a message to the user to indicate that the Kubernetes component
 	// is usually taking longer to complete due to downloading files
 	log.Audit("Configuring Kubernetes component...")
@@ -90,7 +119,7 @@ func (c *Combustion) configureKubernetes(ctx *image.Context) ([]string, error) {
 		return nil, fmt.Errorf("creating kubernetes artefacts path: %w", err)
 	}
 
-	if err = storeKubernetesClusterConfig(cluster, artefactsPath); err != nil {
+	if err = storeKubernetesClusterConfig(cluster, artefactsPath, ctx.ImageDefinition.Kubernetes.Network, version); err != nil {
 		log.AuditComponentFailed(k8sComponentName)
 		return nil, fmt.Errorf("storing cluster config: %w", err)
 	}
@@ -105,12 +134,54 @@ func (c *Combustion) configureKubernetes(ctx *image.Context) ([]string, error) {
 	return []string{script}, nil
 }
 
+// kubernetesProxyValues augments the configured NO_PROXY with the cluster's pod/service CIDRs,
+// service domains, node IPs and API VIP so in-cluster traffic is never proxied.
+func kubernetesProxyValues(ctx *image.Context, cluster *kubernetes.Cluster) map[string]any {
+	proxy := ctx.ImageDefinition.Kubernetes.Proxy
+
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" && proxy.NoProxy == "" {
+		return nil
+	}
+
+	noProxy := []string{".svc", ".cluster.local"}
+	if proxy.NoProxy != "" {
+		noProxy = append(strings.Split(proxy.NoProxy, ","), noProxy...)
+	}
+
+	if cluster.ServerConfig != nil {
+		if cidr, ok := cluster.ServerConfig["cluster-cidr"].(string); ok && cidr != "" {
+			noProxy = append(noProxy, cidr)
+		}
+		if cidr, ok := cluster.ServerConfig["service-cidr"].(string); ok && cidr != "" {
+			noProxy = append(noProxy, cidr)
+		}
+	}
+
+	if apiVIP := ctx.ImageDefinition.Kubernetes.Network.APIVIP; apiVIP != "" {
+		noProxy = append(noProxy, apiVIP)
+	}
+
+	for _, node := range ctx.ImageDefinition.Kubernetes.Nodes {
+		if node.IP != "" {
+			noProxy = append(noProxy, node.IP)
+		}
+	}
+
+	return map[string]any{
+		"httpProxy":  proxy.HTTPProxy,
+		"httpsProxy": proxy.HTTPSProxy,
+		"noProxy":    strings.Join(noProxy, ","),
+	}
+}
+
 func (c *Combustion) kubernetesConfigurator(version string) func(*image.Context, *kubernetes.Cluster) (string, error) {
 	switch {
 	case strings.Contains(version, image.KubernetesDistroRKE2):
 		return c.configureRKE2
 	case strings.Contains(version, image.KubernetesDistroK3S):
 		return c.configureK3S
+	case strings.Contains(version, image.KubernetesDistroKubeadm):
+		return c.configureKubeadm
 	default:
 		return nil
 	}
@@ -145,6 +216,11 @@ func (c *Combustion) configureK3S(ctx *image.Context, cluster *kubernetes.Cluste
 		return "", fmt.Errorf("configuring kubernetes manifests: %w", err)
 	}
 
+	trustedCAsPath, err := c.configureTrustedCAs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("configuring trusted CAs: %w", err)
+	}
+
 	templateValues := map[string]any{
 		"installScript":   installScript,
 		"apiVIP":          ctx.ImageDefinition.Kubernetes.Network.APIVIP,
@@ -154,6 +230,8 @@ func (c *Combustion) configureK3S(ctx *image.Context, cluster *kubernetes.Cluste
 		"manifestsPath":   manifestsPath,
 		"configFilePath":  prependArtefactPath(k8sDir),
 		"registryMirrors": prependArtefactPath(filepath.Join(k8sDir, registryMirrorsFileName)),
+		"proxy":           kubernetesProxyValues(ctx, cluster),
+		"trustedCAsPath":  trustedCAsPath,
 	}
 
 	singleNode := len(ctx.ImageDefinition.Kubernetes.Nodes) < 2
@@ -240,6 +318,18 @@ func (c *Combustion) configureRKE2(ctx *image.Context, cluster *kubernetes.Clust
 		return "", fmt.Errorf("configuring kubernetes manifests: %w", err)
 	}
 
+	proxy := kubernetesProxyValues(ctx, cluster)
+
+	proxyDropInFile, err := storeRKE2ProxyDropIn(ctx, proxy)
+	if err != nil {
+		return "", fmt.Errorf("storing RKE2 proxy drop-in: %w", err)
+	}
+
+	trustedCAsPath, err := c.configureTrustedCAs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("configuring trusted CAs: %w", err)
+	}
+
 	templateValues := map[string]any{
 		"installScript":   installScript,
 		"apiVIP":          ctx.ImageDefinition.Kubernetes.Network.APIVIP,
@@ -249,6 +339,9 @@ func (c *Combustion) configureRKE2(ctx *image.Context, cluster *kubernetes.Clust
 		"manifestsPath":   manifestsPath,
 		"configFilePath":  prependArtefactPath(k8sDir),
 		"registryMirrors": prependArtefactPath(filepath.Join(k8sDir, registryMirrorsFileName)),
+		"proxy":           proxy,
+		"proxyDropInFile": proxyDropInFile,
+		"trustedCAsPath":  trustedCAsPath,
 	}
 
 	singleNode := len(ctx.ImageDefinition.Kubernetes.Nodes) < 2
@@ -269,6 +362,103 @@ func (c *Combustion) configureRKE2(ctx *image.Context, cluster *kubernetes.Clust
 	return storeKubernetesInstaller(ctx, "multi-node-rke2", rke2MultiNodeInstaller, templateValues)
 }
 
+func (c *Combustion) configureKubeadm(ctx *image.Context, cluster *kubernetes.Cluster) (string, error) {
+	zap.S().Info("Configuring kubeadm cluster")
+
+	binaryPath, imagesPath, err := c.downloadKubeadmArtefacts(ctx)
+	if err != nil {
+		return "", fmt.Errorf("downloading kubeadm artefacts: %w", err)
+	}
+
+	manifestsPath, err := c.configureManifests(ctx)
+	if err != nil {
+		return "", fmt.Errorf("configuring kubernetes manifests: %w", err)
+	}
+
+	trustedCAsPath, err := c.configureTrustedCAs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("configuring trusted CAs: %w", err)
+	}
+
+	templateValues := map[string]any{
+		"apiVIP":                ctx.ImageDefinition.Kubernetes.Network.APIVIP,
+		"apiHost":               ctx.ImageDefinition.Kubernetes.Network.APIHost,
+		"binaryPath":            binaryPath,
+		"imagesPath":            imagesPath,
+		"manifestsPath":         manifestsPath,
+		"configFilePath":        prependArtefactPath(k8sDir),
+		"registryMirrors":       prependArtefactPath(filepath.Join(k8sDir, registryMirrorsFileName)),
+		"initialiserConfigFile": k8sInitServerConfigFile,
+		"proxy":                 kubernetesProxyValues(ctx, cluster),
+		"trustedCAsPath":        trustedCAsPath,
+	}
+
+	singleNode := len(ctx.ImageDefinition.Kubernetes.Nodes) < 2
+	if singleNode {
+		if ctx.ImageDefinition.Kubernetes.Network.APIVIP == "" {
+			zap.S().Info("Virtual IP address for kubeadm cluster is not provided and will not be configured")
+		}
+
+		return storeKubernetesInstaller(ctx, "single-node-kubeadm", kubeadmSingleNodeInstaller, templateValues)
+	}
+
+	templateValues["nodes"] = ctx.ImageDefinition.Kubernetes.Nodes
+	templateValues["initialiser"] = cluster.InitialiserName
+	templateValues["configFile"] = k8sServerConfigFile
+	templateValues["agentConfigFile"] = k8sAgentConfigFile
+
+	return storeKubernetesInstaller(ctx, "multi-node-kubeadm", kubeadmMultiNodeInstaller, templateValues)
+}
+
+func (c *Combustion) downloadKubeadmArtefacts(ctx *image.Context) (binaryPath, imagesPath string, err error) {
+	imagesPath = filepath.Join(k8sDir, k8sImagesDir)
+	imagesDestination := filepath.Join(ctx.ArtefactsDir, imagesPath)
+	if err = os.MkdirAll(imagesDestination, os.ModePerm); err != nil {
+		return "", "", fmt.Errorf("creating kubernetes images dir: %w", err)
+	}
+
+	installPath := filepath.Join(k8sDir, k8sInstallDir)
+	installDestination := filepath.Join(ctx.ArtefactsDir, installPath)
+	if err = os.MkdirAll(installDestination, os.ModePerm); err != nil {
+		return "", "", fmt.Errorf("creating kubernetes install dir: %w", err)
+	}
+
+	if err = c.KubernetesArtefactDownloader.DownloadKubeadmArtefacts(
+		ctx.ImageDefinition.Image.Arch,
+		ctx.ImageDefinition.Kubernetes.Version,
+		installDestination,
+		imagesDestination,
+	); err != nil {
+		return "", "", fmt.Errorf("downloading artefacts: %w", err)
+	}
+
+	// Fetches the kubeadm, kubelet and kubectl binaries together with the
+	// control plane component images (etcd, kube-apiserver,
+	// kube-controller-manager, kube-scheduler, kube-proxy, pause, coredns)
+	// required to bring up the cluster fully air-gapped.
+	return prependArtefactPath(installPath), prependArtefactPath(imagesPath), nil
+}
+
+// storeRKE2ProxyDropIn writes the systemd drop-in for the rke2-server unit.
+// It is a no-op when no proxy has been configured.
+func storeRKE2ProxyDropIn(ctx *image.Context, proxy map[string]any) (string, error) {
+	if proxy == nil {
+		return "", nil
+	}
+
+	dropIn := fmt.Sprintf("[Service]\nEnvironment=\"HTTP_PROXY=%s\"\nEnvironment=\"HTTPS_PROXY=%s\"\nEnvironment=\"NO_PROXY=%s\"\n",
+		proxy["httpProxy"], proxy["httpsProxy"], proxy["noProxy"])
+
+	artefactsPath := kubernetesArtefactsPath(ctx)
+	dropInPath := filepath.Join(artefactsPath, rke2ProxyDropInFile)
+
+	if err := os.WriteFile(dropInPath, []byte(dropIn), fileio.NonExecutablePerms); err != nil {
+		return "", fmt.Errorf("writing proxy drop-in file: %w", err)
+	}
+
+	return prependArtefactPath(filepath.Join(k8sDir, rke2ProxyDropInFile)), nil
+}
+
 func storeKubernetesInstaller(ctx *image.Context, templateName, templateContents string, templateValues any) (string, error) {
 	data, err := template.Parse(templateName, templateContents, templateValues)
 	if err != nil {
@@ -315,19 +505,100 @@ func (c *Combustion) downloadRKE2Artefacts(ctx *image.Context, cluster *kubernet
 	return prependArtefactPath(installPath), prependArtefactPath(imagesPath), nil
 }
 
-func kubernetesVIPManifest(k *image.Kubernetes) (string, error) {
-	manifest := struct {
-		APIAddress string
-		RKE2       bool
+// kubernetesVIPManifests renders the kube-vip manifest (ARP or BGP, per Network.VIP.Mode) and,
+// when ServiceIPRange is set, the kube-vip-cloud-provider manifests, returning them keyed by
+// file name along with the images they reference for air-gapped pre-pull.
+func kubernetesVIPManifests(k *image.Kubernetes) (map[string]string, []string, error) {
+	vip := k.Network.VIP
+
+	vipCIDR := "32"
+	if ip := net.ParseIP(k.Network.APIVIP); ip != nil && ip.To4() == nil {
+		vipCIDR = "128"
+	}
+
+	kubeVIPImage := "ghcr.io/kube-vip/kube-vip:" + kubeVIPVersion
+
+	values := struct {
+		Image          string
+		APIAddress     string
+		VIPCIDR        string
+		RKE2           bool
+		Interface      string
+		BGPPeers       string
+		BGPLocalAS     string
+		BGPRouterID    string
+		LeaderElection bool
 	}{
-		APIAddress: k.Network.APIVIP,
-		RKE2:       strings.Contains(k.Version, image.KubernetesDistroRKE2),
+		Image:          kubeVIPImage,
+		APIAddress:     k.Network.APIVIP,
+		VIPCIDR:        vipCIDR,
+		RKE2:           strings.Contains(k.Version, image.KubernetesDistroRKE2),
+		Interface:      vip.Interface,
+		BGPPeers:       formatBGPPeers(vip.BGPPeers, vip.BGPPeerAS),
+		BGPLocalAS:     vip.BGPLocalAS,
+		BGPRouterID:    vip.BGPRouterID,
+		LeaderElection: vip.LeaderElection,
+	}
+
+	tpl, fileName := k8sVIPARPManifest, "k8s-vip-arp.yaml"
+	if vip.Mode == kubeVIPModeBGP {
+		tpl, fileName = k8sVIPBGPManifest, "k8s-vip-bgp.yaml"
 	}
 
-	return template.Parse("k8s-vip", k8sVIPManifest, &manifest)
+	data, err := template.Parse("k8s-vip", tpl, &values)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing VIP manifest: %w", err)
+	}
+
+	manifests := map[string]string{fileName: data}
+	images := []string{kubeVIPImage}
+
+	if vip.ServiceIPRange != "" {
+		kubeVIPCloudProviderImage := "ghcr.io/kube-vip/kube-vip-cloud-provider:" + kubeVIPCloudProviderVersion
+
+		cloudProviderValues := struct {
+			Image          string
+			ServiceIPRange string
+		}{
+			Image:          kubeVIPCloudProviderImage,
+			ServiceIPRange: vip.ServiceIPRange,
+		}
+
+		data, err = template.Parse("kube-vip-cloud-provider", kubeVIPCloudProviderManifest, &cloudProviderValues)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing kube-vip-cloud-provider manifest: %w", err)
+		}
+
+		manifests["kube-vip-cloud-provider.yaml"] = data
+		images = append(images, kubeVIPCloudProviderImage)
+	}
+
+	return manifests, images, nil
 }
 
-func storeKubernetesClusterConfig(cluster *kubernetes.Cluster, destPath string) error {
+// formatBGPPeers renders kube-vip's bgp_peers value: a comma-separated
+// "address:AS:password:multihop" list, with peerAS applied to every peer.
+func formatBGPPeers(peers []string, peerAS string) string {
+	formatted := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		formatted = append(formatted, fmt.Sprintf("%s:%s::false", peer, peerAS))
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+func storeKubernetesClusterConfig(cluster *kubernetes.Cluster, destPath string, network image.KubernetesNetwork, version string) error {
+	mergeAPIServerSANs(cluster.ServerConfig, network.APIServerSANs)
+	mergeAPIServerSANs(cluster.InitialiserConfig, network.APIServerSANs)
+
+	mergeNetworkConfig(cluster.ServerConfig, network)
+	mergeNetworkConfig(cluster.InitialiserConfig, network)
+	mergeAgentNetworkConfig(cluster.AgentConfig, network)
+
+	if strings.Contains(version, image.KubernetesDistroKubeadm) {
+		return storeKubeadmClusterConfig(cluster, destPath)
+	}
+
 	serverConfig := filepath.Join(destPath, k8sServerConfigFile)
 	if err := storeKubernetesConfig(cluster.ServerConfig, serverConfig); err != nil {
 		return fmt.Errorf("storing server config file: %w", err)
@@ -352,6 +623,273 @@ func storeKubernetesClusterConfig(cluster *kubernetes.Cluster, destPath string)
 	return nil
 }
 
+// storeKubeadmClusterConfig translates the generic server/initialiser/agent config maps
+// produced by kubernetes.NewCluster into the multi-document kubeadm.k8s.io YAML kubeadm itself
+// expects, rather than writing them out as the single-document RKE2/K3s-flavoured config files.
+// server.yaml/agent.yaml become JoinConfiguration documents for control-plane/worker joins,
+// and init_server.yaml becomes a ClusterConfiguration+InitConfiguration document pair.
+func storeKubeadmClusterConfig(cluster *kubernetes.Cluster, destPath string) error {
+	if cluster.InitialiserConfig != nil {
+		data, err := marshalKubeadmDocuments(
+			kubeadmClusterConfiguration(cluster.InitialiserConfig),
+			kubeadmInitConfiguration(cluster.InitialiserConfig),
+		)
+		if err != nil {
+			return fmt.Errorf("marshaling kubeadm init configuration: %w", err)
+		}
+
+		initialiserConfig := filepath.Join(destPath, k8sInitServerConfigFile)
+		if err = os.WriteFile(initialiserConfig, data, fileio.NonExecutablePerms); err != nil {
+			return fmt.Errorf("storing init server config file: %w", err)
+		}
+	}
+
+	data, err := marshalKubeadmDocuments(kubeadmJoinConfiguration(cluster.ServerConfig, true))
+	if err != nil {
+		return fmt.Errorf("marshaling kubeadm server join configuration: %w", err)
+	}
+
+	serverConfig := filepath.Join(destPath, k8sServerConfigFile)
+	if err = os.WriteFile(serverConfig, data, fileio.NonExecutablePerms); err != nil {
+		return fmt.Errorf("storing server config file: %w", err)
+	}
+
+	if cluster.AgentConfig != nil {
+		data, err = marshalKubeadmDocuments(kubeadmJoinConfiguration(cluster.AgentConfig, false))
+		if err != nil {
+			return fmt.Errorf("marshaling kubeadm agent join configuration: %w", err)
+		}
+
+		agentConfig := filepath.Join(destPath, k8sAgentConfigFile)
+		if err = os.WriteFile(agentConfig, data, fileio.NonExecutablePerms); err != nil {
+			return fmt.Errorf("storing agent config file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func kubeadmClusterConfiguration(config map[string]any) map[string]any {
+	clusterConfig := map[string]any{
+		"apiVersion": "kubeadm.k8s.io/v1beta3",
+		"kind":       "ClusterConfiguration",
+	}
+
+	networking := map[string]any{}
+	if cidr, ok := config["cluster-cidr"]; ok {
+		networking["podSubnet"] = cidr
+	}
+	if cidr, ok := config["service-cidr"]; ok {
+		networking["serviceSubnet"] = cidr
+	}
+	if len(networking) > 0 {
+		clusterConfig["networking"] = networking
+	}
+
+	if sans := toStringSlice(config["tls-san"]); len(sans) > 0 {
+		clusterConfig["apiServer"] = map[string]any{"certSANs": sans}
+	}
+
+	return clusterConfig
+}
+
+func kubeadmInitConfiguration(config map[string]any) map[string]any {
+	initConfig := map[string]any{
+		"apiVersion": "kubeadm.k8s.io/v1beta3",
+		"kind":       "InitConfiguration",
+	}
+
+	if token, ok := config["token"]; ok {
+		initConfig["bootstrapTokens"] = []map[string]any{{"token": token}}
+	}
+
+	if nodeIP, ok := config["node-ip"]; ok {
+		initConfig["localAPIEndpoint"] = map[string]any{"advertiseAddress": nodeIP}
+	}
+
+	return initConfig
+}
+
+func kubeadmJoinConfiguration(config map[string]any, controlPlane bool) map[string]any {
+	joinConfig := map[string]any{
+		"apiVersion": "kubeadm.k8s.io/v1beta3",
+		"kind":       "JoinConfiguration",
+	}
+
+	bootstrapToken := map[string]any{
+		"token":             config["token"],
+		"apiServerEndpoint": config["server"],
+	}
+
+	if hash, ok := kubeadmCACertHash(config); ok {
+		bootstrapToken["caCertHashes"] = []string{hash}
+	} else {
+		bootstrapToken["unsafeSkipCAVerification"] = true
+	}
+
+	joinConfig["discovery"] = map[string]any{"bootstrapToken": bootstrapToken}
+
+	if controlPlane {
+		joinConfig["controlPlane"] = map[string]any{
+			"localAPIEndpoint": map[string]any{"advertiseAddress": config["node-ip"]},
+		}
+	}
+
+	return joinConfig
+}
+
+// kubeadmCACertHash computes the "sha256:<hex>" discovery token CA cert hash kubeadm
+// uses to verify the API server's identity during join, in place of unsafeSkipCAVerification.
+func kubeadmCACertHash(config map[string]any) (string, bool) {
+	pemData, ok := config["ca-cert"].(string)
+	if !ok || pemData == "" {
+		return "", false
+	}
+
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return "", false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return fmt.Sprintf("sha256:%x", sum), true
+}
+
+// marshalKubeadmDocuments renders one or more kubeadm config objects as a single
+// "---"-separated multi-document YAML stream.
+func marshalKubeadmDocuments(docs ...map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for i, doc := range docs {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("serializing kubeadm document: %w", err)
+		}
+
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mergeAPIServerSANs folds additional SANs into the cluster's tls-san list without duplicating
+// entries kubernetes.NewCluster already produced. The existing list may hold []string or
+// []interface{} depending on how the config map was built, so both shapes are accepted.
+func mergeAPIServerSANs(config map[string]any, additionalSANs []string) {
+	if config == nil || len(additionalSANs) == 0 {
+		return
+	}
+
+	existing := toStringSlice(config["tls-san"])
+
+	seen := make(map[string]bool, len(existing))
+	for _, san := range existing {
+		seen[san] = true
+	}
+
+	for _, san := range additionalSANs {
+		if san != "" && !seen[san] {
+			existing = append(existing, san)
+			seen[san] = true
+		}
+	}
+
+	config["tls-san"] = existing
+}
+
+// mergeNetworkConfig overlays the image definition's pod/service CIDRs, cluster DNS and
+// node-CIDR mask size onto a server/initialiser config map. A dual-stack pod or service CIDR
+// also switches bind-address to "::". For cluster.AgentConfig use mergeAgentNetworkConfig
+// instead: these are apiserver/controller-manager flags, not valid on an agent-only process.
+func mergeNetworkConfig(config map[string]any, network image.KubernetesNetwork) {
+	if config == nil {
+		return
+	}
+
+	if network.PodCIDR != "" {
+		config["cluster-cidr"] = network.PodCIDR
+	}
+
+	if network.ServiceCIDR != "" {
+		config["service-cidr"] = network.ServiceCIDR
+	}
+
+	if network.ClusterDNS != "" {
+		config["cluster-dns"] = network.ClusterDNS
+	}
+
+	if network.NodeCIDRMaskSize != "" {
+		config["node-cidr-mask-size"] = network.NodeCIDRMaskSize
+	}
+
+	if isDualStack(network.PodCIDR) || isDualStack(network.ServiceCIDR) {
+		config["bind-address"] = "::"
+	}
+}
+
+// mergeAgentNetworkConfig overlays cluster DNS onto an agent-only config map. Unlike
+// mergeNetworkConfig it skips cluster-cidr/service-cidr/node-cidr-mask-size/bind-address,
+// which rke2/k3s agents don't recognise and refuse to start with.
+func mergeAgentNetworkConfig(config map[string]any, network image.KubernetesNetwork) {
+	if config == nil {
+		return
+	}
+
+	if network.ClusterDNS != "" {
+		config["cluster-dns"] = network.ClusterDNS
+	}
+}
+
+// isDualStack reports whether a comma-separated CIDR list contains both an IPv4 and an IPv6 member.
+func isDualStack(cidrs string) bool {
+	if cidrs == "" {
+		return false
+	}
+
+	var sawIPv4, sawIPv6 bool
+	for _, cidr := range strings.Split(cidrs, ",") {
+		ip, _, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			continue
+		}
+
+		if ip.To4() == nil {
+			sawIPv6 = true
+		} else {
+			sawIPv4 = true
+		}
+	}
+
+	return sawIPv4 && sawIPv6
+}
+
+// toStringSlice normalises a []string or []interface{} into a []string, skipping non-string entries.
+func toStringSlice(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, entry := range v {
+			if s, ok := entry.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
 func storeKubernetesConfig(config map[string]any, configPath string) error {
 	data, err := yaml.Marshal(config)
 	if err != nil {
@@ -361,6 +899,52 @@ func storeKubernetesConfig(config map[string]any, configPath string) error {
 	return os.WriteFile(configPath, data, fileio.NonExecutablePerms)
 }
 
+// applyKubernetesProxyConfig passes the proxy settings directly to the Registry and artefact
+// downloader instead of exporting them via os.Setenv, which net/http.ProxyFromEnvironment only
+// reads once per process. Must run before either dependency makes its first request.
+func (c *Combustion) applyKubernetesProxyConfig(ctx *image.Context) {
+	proxy := ctx.ImageDefinition.Kubernetes.Proxy
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" && proxy.NoProxy == "" {
+		return
+	}
+
+	if c.Registry != nil {
+		c.Registry.SetProxy(proxy)
+	}
+
+	c.KubernetesArtefactDownloader.SetProxy(proxy)
+}
+
+// configureTrustedCAs copies the PEM-encoded CA bundles referenced by Kubernetes.TrustedCAs
+// into the artefacts so the install script can trust them before kubelet/containerd start.
+func (c *Combustion) configureTrustedCAs(ctx *image.Context) (string, error) {
+	cas := ctx.ImageDefinition.Kubernetes.TrustedCAs
+	if len(cas) == 0 {
+		return "", nil
+	}
+
+	destDir := filepath.Join(kubernetesArtefactsPath(ctx), k8sTrustedCADir)
+	if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("creating trusted CA dir: %w", err)
+	}
+
+	for _, ca := range cas {
+		src := filepath.Join(ctx.ImageConfigDir, ca)
+
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return "", fmt.Errorf("reading trusted CA %q: %w", ca, err)
+		}
+
+		dest := filepath.Join(destDir, filepath.Base(ca))
+		if err = os.WriteFile(dest, data, fileio.NonExecutablePerms); err != nil {
+			return "", fmt.Errorf("copying trusted CA %q: %w", ca, err)
+		}
+	}
+
+	return prependArtefactPath(filepath.Join(k8sDir, k8sTrustedCADir)), nil
+}
+
 func (c *Combustion) configureManifests(ctx *image.Context) (string, error) {
 	var manifestsPathPopulated bool
 
@@ -372,14 +956,20 @@ func (c *Combustion) configureManifests(ctx *image.Context) (string, error) {
 			return "", fmt.Errorf("creating manifests destination dir: %w", err)
 		}
 
-		manifest, err := kubernetesVIPManifest(&ctx.ImageDefinition.Kubernetes)
+		vipManifests, vipImages, err := kubernetesVIPManifests(&ctx.ImageDefinition.Kubernetes)
 		if err != nil {
-			return "", fmt.Errorf("parsing VIP manifest: %w", err)
+			return "", fmt.Errorf("parsing VIP manifests: %w", err)
+		}
+
+		for fileName, data := range vipManifests {
+			manifestPath := filepath.Join(manifestDestDir, fileName)
+			if err = os.WriteFile(manifestPath, []byte(data), fileio.NonExecutablePerms); err != nil {
+				return "", fmt.Errorf("storing VIP manifest %q: %w", fileName, err)
+			}
 		}
 
-		manifestPath := filepath.Join(manifestDestDir, "k8s-vip.yaml")
-		if err = os.WriteFile(manifestPath, []byte(manifest), fileio.NonExecutablePerms); err != nil {
-			return "", fmt.Errorf("storing VIP manifest: %w", err)
+		if c.Registry != nil {
+			c.Registry.AppendImages(vipImages...)
 		}
 
 		manifestsPathPopulated = true
@@ -420,6 +1010,37 @@ func (c *Combustion) configureManifests(ctx *image.Context) (string, error) {
 		}
 	}
 
+	for _, selected := range ctx.ImageDefinition.Kubernetes.Addons {
+		addon, ok := addons.Get(selected.Name)
+		if !ok {
+			return "", fmt.Errorf("unknown kubernetes addon: %s", selected.Name)
+		}
+
+		values := c.addonValues(selected.Values)
+
+		manifests, images, err := addon.Render(values)
+		if err != nil {
+			return "", fmt.Errorf("rendering %q addon: %w", selected.Name, err)
+		}
+
+		if err = os.MkdirAll(manifestDestDir, os.ModePerm); err != nil {
+			return "", fmt.Errorf("creating manifests destination dir: %w", err)
+		}
+
+		for _, manifest := range manifests {
+			manifestPath := filepath.Join(manifestDestDir, manifest.Name)
+			if err = os.WriteFile(manifestPath, manifest.Data, fileio.NonExecutablePerms); err != nil {
+				return "", fmt.Errorf("storing %q addon manifest: %w", selected.Name, err)
+			}
+		}
+
+		if c.Registry != nil {
+			c.Registry.AppendImages(images...)
+		}
+
+		manifestsPathPopulated = true
+	}
+
 	if !manifestsPathPopulated {
 		return "", nil
 	}
@@ -427,6 +1048,23 @@ func (c *Combustion) configureManifests(ctx *image.Context) (string, error) {
 	return prependArtefactPath(manifestsPath), nil
 }
 
+// addonValues returns a copy of values with the embedded Helm chart mirror's address merged in,
+// falling back to the add-on's upstream repo unchanged when no mirror is configured.
+func (c *Combustion) addonValues(values map[string]any) map[string]any {
+	merged := make(map[string]any, len(values)+1)
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	if c.Registry != nil {
+		if mirror := c.Registry.HelmMirror(); mirror != "" {
+			merged["helmRepoMirror"] = mirror
+		}
+	}
+
+	return merged
+}
+
 func KubernetesConfigPath(ctx *image.Context) string {
 	return filepath.Join(ctx.ImageConfigDir, k8sDir, k8sConfigDir, k8sServerConfigFile)
 }
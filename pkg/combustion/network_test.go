@@ -0,0 +1,123 @@
+package combustion
+
+import (
+	"testing"
+
+	"github.com/suse-edge/edge-image-builder/pkg/image"
+)
+
+func TestIsDualStack(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidrs    string
+		expected bool
+	}{
+		{name: "empty string is not dual-stack", cidrs: "", expected: false},
+		{name: "single IPv4 CIDR is not dual-stack", cidrs: "10.42.0.0/16", expected: false},
+		{name: "single IPv6 CIDR is not dual-stack", cidrs: "fd00:42::/56", expected: false},
+		{name: "IPv4,IPv6 pair is dual-stack", cidrs: "10.42.0.0/16,fd00:42::/56", expected: true},
+		{name: "IPv6,IPv4 pair is dual-stack regardless of order", cidrs: "fd00:42::/56,10.42.0.0/16", expected: true},
+		{name: "two IPv4 CIDRs is not dual-stack", cidrs: "10.42.0.0/16,10.44.0.0/16", expected: false},
+		{name: "invalid entries are ignored", cidrs: "not-a-cidr,10.42.0.0/16", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isDualStack(test.cidrs); actual != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		network  image.KubernetesNetwork
+		expected map[string]any
+	}{
+		{
+			name: "single-stack pod/service CIDR, DNS and mask size",
+			network: image.KubernetesNetwork{
+				PodCIDR:          "10.42.0.0/16",
+				ServiceCIDR:      "10.43.0.0/16",
+				ClusterDNS:       "10.43.0.10",
+				NodeCIDRMaskSize: "24",
+			},
+			expected: map[string]any{
+				"cluster-cidr":        "10.42.0.0/16",
+				"service-cidr":        "10.43.0.0/16",
+				"cluster-dns":         "10.43.0.10",
+				"node-cidr-mask-size": "24",
+			},
+		},
+		{
+			name: "dual-stack pod CIDR sets bind-address",
+			network: image.KubernetesNetwork{
+				PodCIDR:     "10.42.0.0/16,fd00:42::/56",
+				ServiceCIDR: "10.43.0.0/16",
+			},
+			expected: map[string]any{
+				"cluster-cidr": "10.42.0.0/16,fd00:42::/56",
+				"service-cidr": "10.43.0.0/16",
+				"bind-address": "::",
+			},
+		},
+		{
+			name:     "zero-value network leaves config untouched",
+			network:  image.KubernetesNetwork{},
+			expected: map[string]any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]any{}
+			mergeNetworkConfig(config, test.network)
+
+			for key, value := range test.expected {
+				if config[key] != value {
+					t.Fatalf("expected %s=%v, got %v", key, value, config[key])
+				}
+			}
+
+			if len(config) != len(test.expected) {
+				t.Fatalf("expected config %v, got %v", test.expected, config)
+			}
+		})
+	}
+
+	t.Run("nil config is a no-op", func(t *testing.T) {
+		mergeNetworkConfig(nil, image.KubernetesNetwork{PodCIDR: "10.42.0.0/16"})
+	})
+}
+
+func TestMergeAgentNetworkConfig(t *testing.T) {
+	network := image.KubernetesNetwork{
+		PodCIDR:          "10.42.0.0/16",
+		ServiceCIDR:      "10.43.0.0/16",
+		ClusterDNS:       "10.43.0.10",
+		NodeCIDRMaskSize: "24",
+	}
+
+	config := map[string]any{}
+	mergeAgentNetworkConfig(config, network)
+
+	expected := map[string]any{"cluster-dns": "10.43.0.10"}
+	if len(config) != len(expected) || config["cluster-dns"] != expected["cluster-dns"] {
+		t.Fatalf("expected only cluster-dns to be set on agent config, got %v", config)
+	}
+
+	if _, ok := config["cluster-cidr"]; ok {
+		t.Fatalf("expected cluster-cidr to be absent from agent config, got %v", config)
+	}
+	if _, ok := config["service-cidr"]; ok {
+		t.Fatalf("expected service-cidr to be absent from agent config, got %v", config)
+	}
+	if _, ok := config["node-cidr-mask-size"]; ok {
+		t.Fatalf("expected node-cidr-mask-size to be absent from agent config, got %v", config)
+	}
+	if _, ok := config["bind-address"]; ok {
+		t.Fatalf("expected bind-address to be absent from agent config, got %v", config)
+	}
+}
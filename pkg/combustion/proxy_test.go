@@ -0,0 +1,84 @@
+package combustion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suse-edge/edge-image-builder/pkg/image"
+	"github.com/suse-edge/edge-image-builder/pkg/kubernetes"
+)
+
+func TestKubernetesProxyValues(t *testing.T) {
+	tests := []struct {
+		name            string
+		proxy           image.Proxy
+		serverConfig    map[string]any
+		apiVIP          string
+		nodeIPs         []string
+		expectNil       bool
+		expectedNoProxy []string
+	}{
+		{
+			name:      "no proxy configuration returns nil",
+			proxy:     image.Proxy{},
+			expectNil: true,
+		},
+		{
+			name:            "auto-augments with defaults when no CIDRs or nodes are set",
+			proxy:           image.Proxy{HTTPProxy: "http://proxy:3128"},
+			expectedNoProxy: []string{".svc", ".cluster.local"},
+		},
+		{
+			name:            "user-provided NO_PROXY entries come first",
+			proxy:           image.Proxy{HTTPProxy: "http://proxy:3128", NoProxy: "custom.example.com"},
+			expectedNoProxy: []string{"custom.example.com", ".svc", ".cluster.local"},
+		},
+		{
+			name:            "adds cluster and service CIDRs from the server config",
+			proxy:           image.Proxy{HTTPProxy: "http://proxy:3128"},
+			serverConfig:    map[string]any{"cluster-cidr": "10.42.0.0/16", "service-cidr": "10.43.0.0/16"},
+			expectedNoProxy: []string{".svc", ".cluster.local", "10.42.0.0/16", "10.43.0.0/16"},
+		},
+		{
+			name:            "adds the API VIP and node IPs",
+			proxy:           image.Proxy{HTTPProxy: "http://proxy:3128"},
+			apiVIP:          "192.168.1.100",
+			nodeIPs:         []string{"192.168.1.10", "192.168.1.11"},
+			expectedNoProxy: []string{".svc", ".cluster.local", "192.168.1.100", "192.168.1.10", "192.168.1.11"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &image.Context{
+				ImageDefinition: &image.Definition{
+					Kubernetes: image.Kubernetes{
+						Proxy: test.proxy,
+					},
+				},
+			}
+			ctx.ImageDefinition.Kubernetes.Network.APIVIP = test.apiVIP
+
+			for _, ip := range test.nodeIPs {
+				ctx.ImageDefinition.Kubernetes.Nodes = append(ctx.ImageDefinition.Kubernetes.Nodes, image.Node{IP: ip})
+			}
+
+			cluster := &kubernetes.Cluster{ServerConfig: test.serverConfig}
+
+			values := kubernetesProxyValues(ctx, cluster)
+
+			if test.expectNil {
+				if values != nil {
+					t.Fatalf("expected nil values, got %v", values)
+				}
+				return
+			}
+
+			actual, _ := values["noProxy"].(string)
+			expected := strings.Join(test.expectedNoProxy, ",")
+			if actual != expected {
+				t.Fatalf("expected noProxy %q, got %q", expected, actual)
+			}
+		})
+	}
+}
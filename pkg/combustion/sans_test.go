@@ -0,0 +1,70 @@
+package combustion
+
+import "testing"
+
+func TestMergeAPIServerSANs(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]any
+		additionalSANs []string
+		expected       []string
+	}{
+		{
+			name:           "nil config is a no-op",
+			config:         nil,
+			additionalSANs: []string{"san.example.com"},
+			expected:       nil,
+		},
+		{
+			name:           "no additional SANs is a no-op",
+			config:         map[string]any{"tls-san": []string{"existing.example.com"}},
+			additionalSANs: nil,
+			expected:       []string{"existing.example.com"},
+		},
+		{
+			name:           "appends new SANs to an existing []string list",
+			config:         map[string]any{"tls-san": []string{"existing.example.com"}},
+			additionalSANs: []string{"new.example.com"},
+			expected:       []string{"existing.example.com", "new.example.com"},
+		},
+		{
+			name:           "skips duplicates already present",
+			config:         map[string]any{"tls-san": []string{"existing.example.com"}},
+			additionalSANs: []string{"existing.example.com", "new.example.com"},
+			expected:       []string{"existing.example.com", "new.example.com"},
+		},
+		{
+			name:           "handles []interface{} as produced by a generic YAML decode",
+			config:         map[string]any{"tls-san": []interface{}{"existing.example.com"}},
+			additionalSANs: []string{"new.example.com"},
+			expected:       []string{"existing.example.com", "new.example.com"},
+		},
+		{
+			name:           "starts fresh when tls-san is absent",
+			config:         map[string]any{},
+			additionalSANs: []string{"new.example.com"},
+			expected:       []string{"new.example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mergeAPIServerSANs(test.config, test.additionalSANs)
+
+			if test.config == nil {
+				return
+			}
+
+			actual := toStringSlice(test.config["tls-san"])
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, actual)
+			}
+
+			for i, san := range test.expected {
+				if actual[i] != san {
+					t.Fatalf("expected %v, got %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package combustion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suse-edge/edge-image-builder/pkg/image"
+)
+
+func TestKubernetesVIPManifests(t *testing.T) {
+	tests := []struct {
+		name           string
+		k              *image.Kubernetes
+		expectFileName string
+		expectContains []string
+	}{
+		{
+			name: "defaults to ARP mode with an IPv4 /32 VIP CIDR",
+			k: &image.Kubernetes{
+				Network: image.KubernetesNetwork{APIVIP: "192.168.1.100"},
+			},
+			expectFileName: "k8s-vip-arp.yaml",
+			expectContains: []string{`value: "192.168.1.100"`, `value: "32"`},
+		},
+		{
+			name: "detects an IPv6 VIP and renders a /128 CIDR",
+			k: &image.Kubernetes{
+				Network: image.KubernetesNetwork{APIVIP: "2001:db8::100"},
+			},
+			expectFileName: "k8s-vip-arp.yaml",
+			expectContains: []string{`value: "128"`},
+		},
+		{
+			name: "honours an explicit leader election override",
+			k: &image.Kubernetes{
+				Network: image.KubernetesNetwork{
+					APIVIP: "192.168.1.100",
+					VIP:    image.VIP{LeaderElection: true},
+				},
+			},
+			expectFileName: "k8s-vip-arp.yaml",
+			expectContains: []string{"name: vip_leaderelection\n          value: \"true\""},
+		},
+		{
+			name: "switches to the BGP manifest when Network.VIP.Mode is bgp",
+			k: &image.Kubernetes{
+				Network: image.KubernetesNetwork{
+					APIVIP: "192.168.1.100",
+					VIP: image.VIP{
+						Mode:        kubeVIPModeBGP,
+						BGPPeers:    []string{"192.168.1.1"},
+						BGPPeerAS:   "65000",
+						BGPLocalAS:  "65001",
+						BGPRouterID: "192.168.1.2",
+					},
+				},
+			},
+			expectFileName: "k8s-vip-bgp.yaml",
+			expectContains: []string{
+				"192.168.1.1:65000::false",
+				`value: "65001"`,
+				`value: "192.168.1.2"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifests, _, err := kubernetesVIPManifests(test.k)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, ok := manifests[test.expectFileName]
+			if !ok {
+				t.Fatalf("expected manifest %q to be rendered, got keys %v", test.expectFileName, manifests)
+			}
+
+			for _, substr := range test.expectContains {
+				if !strings.Contains(data, substr) {
+					t.Fatalf("expected manifest to contain %q, got:\n%s", substr, data)
+				}
+			}
+		})
+	}
+}